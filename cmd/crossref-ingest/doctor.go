@@ -0,0 +1,50 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"log"
+	"os"
+
+	_ "github.com/lib/pq"
+
+	"language-in-genetics/language-analysis/internal/doctor"
+)
+
+func runDoctor(args []string) {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	dbConnStr := fs.String("dbconn", "host=/var/run/postgresql dbname=crossref sslmode=disable", "PostgreSQL connection string")
+	format := fs.String("format", "text", "Report format: text or json")
+	fs.Parse(args)
+
+	if *format != "text" && *format != "json" {
+		log.Fatalf("unknown -format %q: must be text or json", *format)
+	}
+
+	db, err := sql.Open("postgres", *dbConnStr)
+	if err != nil {
+		log.Fatalf("Error connecting to database: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(`SET search_path TO languageingenetics, public`); err != nil {
+		log.Fatalf("Error setting search path: %v", err)
+	}
+
+	report, err := doctor.Run(db)
+	if err != nil {
+		log.Fatalf("Error running doctor checks: %v", err)
+	}
+
+	if *format == "json" {
+		if err := report.WriteJSON(os.Stdout); err != nil {
+			log.Fatalf("Error writing JSON report: %v", err)
+		}
+	} else {
+		report.WriteText(os.Stdout)
+	}
+
+	if report.Failed() {
+		os.Exit(1)
+	}
+}
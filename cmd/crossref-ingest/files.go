@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"language-in-genetics/language-analysis/internal/ingest"
+)
+
+func runFiles(args []string) {
+	fs := flag.NewFlagSet("files", flag.ExitOnError)
+	inputDir := fs.String("dir", ".", "Directory containing .json.gz files")
+	outputDir := fs.String("output", "output", "Directory for output files")
+	workers := fs.Int("workers", 4, "Number of .json.gz files to process concurrently")
+	journalsFlag := fs.String("journals", "", "Comma-separated list of journal names to keep")
+	journalsFile := fs.String("journals-file", "", "YAML file listing journal names to keep")
+	checkpointFile := fs.String("checkpoint", "", "Path to the checkpoint sidecar file (default: <output>/.checkpoint.json)")
+	checkpointEvery := fs.Int("checkpoint-every", 1000, "Save a resume checkpoint every N items")
+	force := fs.Bool("force", false, "Reprocess files even if already marked complete")
+	dryRun := fs.Bool("dry-run", false, "Print what would be processed without writing items or checkpoints; the output directory is still created")
+	fs.Parse(args)
+
+	filter, err := ingest.LoadJournalFilter(*journalsFlag, *journalsFile)
+	if err != nil {
+		log.Fatalf("Error loading journal filter: %v", err)
+	}
+
+	if err := os.MkdirAll(*outputDir, 0755); err != nil {
+		log.Fatalf("Error creating output directory: %v", err)
+	}
+
+	if *checkpointFile == "" {
+		*checkpointFile = filepath.Join(*outputDir, ".checkpoint.json")
+	}
+	store, err := ingest.NewFileCheckpointStore(*checkpointFile)
+	if err != nil {
+		log.Fatalf("Error loading checkpoint file: %v", err)
+	}
+
+	sink := ingest.NewFileSink(*outputDir)
+
+	progress := ingest.NewProgressReporter(5 * time.Second)
+	defer progress.Stop()
+
+	ctx := context.Background()
+	err = ingest.WalkFiles(*inputDir, *workers, func(path string) error {
+		return ingest.IngestFileResumable(ctx, path, sink, filter, progress, store, *force, *dryRun, *checkpointEvery)
+	})
+	if err != nil {
+		log.Fatalf("Error walking directory: %v", err)
+	}
+}
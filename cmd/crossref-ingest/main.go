@@ -0,0 +1,50 @@
+// Command crossref-ingest loads Crossref article metadata into a
+// destination sink: a directory of per-article JSON files, a PostgreSQL
+// database, or a SQLite database. Articles are read from local .json.gz
+// dumps or, for the postgres and sqlite sinks, fetched incrementally from
+// the Crossref REST API.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "files":
+		runFiles(os.Args[2:])
+	case "postgres":
+		runPostgres(os.Args[2:])
+	case "sqlite":
+		runSqlite(os.Args[2:])
+	case "doctor":
+		runDoctor(os.Args[2:])
+	case "-h", "--help", "help":
+		usage()
+	default:
+		fmt.Fprintf(os.Stderr, "unknown subcommand %q\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `crossref-ingest walks Crossref .json.gz dumps and loads articles into a sink.
+
+Usage:
+  crossref-ingest <subcommand> [flags]
+
+Subcommands:
+  files     write one metadata.json per article under -output
+  postgres  bulk-load articles into a PostgreSQL database
+  sqlite    bulk-load articles into a SQLite database
+  doctor    audit an ingested PostgreSQL corpus for consistency problems
+
+Run 'crossref-ingest <subcommand> -h' for subcommand flags.`)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	_ "github.com/lib/pq"
+
+	"language-in-genetics/language-analysis/internal/ingest"
+)
+
+func runPostgres(args []string) {
+	fs := flag.NewFlagSet("postgres", flag.ExitOnError)
+	inputDir := fs.String("dir", ".", "Directory containing .json.gz files (-source=dump only)")
+	dbConnStr := fs.String("dbconn", "host=/var/run/postgresql dbname=crossref sslmode=disable", "PostgreSQL connection string")
+	workers := fs.Int("workers", 4, "Number of files, or journals, to process concurrently")
+	batchSize := fs.Int("batch-size", 10000, "Number of items to COPY into the database per transaction")
+	schemaFlag := fs.String("schema", "raw", "Which tables to populate: raw, normalized, or both")
+	checkpointEvery := fs.Int("checkpoint-every", 10000, "Save a resume checkpoint every N items (-source=dump only)")
+	force := fs.Bool("force", false, "Reprocess files even if already marked complete (-source=dump only)")
+	dryRun := fs.Bool("dry-run", false, "Print what would be processed without ingesting items or saving checkpoints; tables are still created (-source=dump only)")
+	source := fs.String("source", "dump", "Where to read items from: dump or api")
+	mailto := fs.String("mailto", "", "Contact email to send with API requests, for Crossref's polite pool (-source=api only)")
+	since := fs.String("since", "", "Only fetch works indexed on or after this date, YYYY-MM-DD (-source=api only)")
+	fs.Parse(args)
+
+	schema, err := ingest.ParseSchema(*schemaFlag)
+	if err != nil {
+		log.Fatalf("Error parsing -schema: %v", err)
+	}
+
+	db, err := sql.Open("postgres", *dbConnStr)
+	if err != nil {
+		log.Fatalf("Error connecting to database: %v", err)
+	}
+	if err := ingest.InitPostgresSchema(db); err != nil {
+		log.Fatalf("Error initializing database: %v", err)
+	}
+	if schema != ingest.SchemaRaw {
+		if err := ingest.InitNormalizedSchema(db); err != nil {
+			log.Fatalf("Error initializing normalized schema: %v", err)
+		}
+	}
+	if err := ingest.InitProcessedFilesSchema(db); err != nil {
+		log.Fatalf("Error initializing processed_files table: %v", err)
+	}
+
+	progress := ingest.NewProgressReporter(5 * time.Second)
+	defer progress.Stop()
+
+	ctx := context.Background()
+
+	switch *source {
+	case "dump":
+		runPostgresDump(ctx, db, *dbConnStr, *inputDir, *workers, *batchSize, schema, *checkpointEvery, *force, *dryRun, progress)
+	case "api":
+		runPostgresAPI(ctx, db, *dbConnStr, *workers, *batchSize, schema, *mailto, *since, progress)
+	default:
+		log.Fatalf("Unknown -source %q: must be dump or api", *source)
+	}
+
+	db.Close()
+}
+
+func runPostgresDump(ctx context.Context, db *sql.DB, dbConnStr, inputDir string, workers, batchSize int, schema ingest.Schema, checkpointEvery int, force, dryRun bool, progress *ingest.ProgressReporter) {
+	filter, err := ingest.LoadJournalFilterFromDB(db)
+	if err != nil {
+		log.Fatalf("Error loading journal filter: %v", err)
+	}
+
+	store := ingest.NewDBCheckpointStore(db, sq.Dollar)
+
+	err = ingest.WalkFiles(inputDir, workers, func(path string) error {
+		// Each worker gets its own connection so COPY batches from
+		// different files never contend on the same transaction.
+		workerDB, err := sql.Open("postgres", dbConnStr)
+		if err != nil {
+			return fmt.Errorf("error connecting to database: %w", err)
+		}
+		if _, err := workerDB.Exec(`SET search_path TO languageingenetics, public`); err != nil {
+			workerDB.Close()
+			return fmt.Errorf("error setting search path: %w", err)
+		}
+
+		sink := ingest.NewPostgresSink(workerDB, batchSize, schema)
+		defer sink.Close()
+		return ingest.IngestFileResumable(ctx, path, sink, filter, progress, store, force, dryRun, checkpointEvery)
+	})
+	if err != nil {
+		log.Fatalf("Error walking directory: %v", err)
+	}
+}
+
+func runPostgresAPI(ctx context.Context, db *sql.DB, dbConnStr string, workers, batchSize int, schema ingest.Schema, mailto, since string, progress *ingest.ProgressReporter) {
+	journals, err := ingest.ListEnabledJournals(db)
+	if err != nil {
+		log.Fatalf("Error listing enabled journals: %v", err)
+	}
+
+	client := ingest.NewCrossrefAPIClient(mailto)
+
+	err = ingest.WalkJournals(journals, workers, func(journal string) error {
+		// Each worker gets its own connection, same as the dump path.
+		workerDB, err := sql.Open("postgres", dbConnStr)
+		if err != nil {
+			return fmt.Errorf("error connecting to database: %w", err)
+		}
+		if _, err := workerDB.Exec(`SET search_path TO languageingenetics, public`); err != nil {
+			workerDB.Close()
+			return fmt.Errorf("error setting search path: %w", err)
+		}
+
+		sink := ingest.NewPostgresSink(workerDB, batchSize, schema)
+		defer sink.Close()
+		return ingest.IngestJournalAPI(ctx, client, journal, since, sink, progress)
+	})
+	if err != nil {
+		log.Fatalf("Error fetching journals: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	_ "github.com/mattn/go-sqlite3"
+
+	"language-in-genetics/language-analysis/internal/ingest"
+)
+
+func runSqlite(args []string) {
+	fs := flag.NewFlagSet("sqlite", flag.ExitOnError)
+	inputDir := fs.String("dir", ".", "Directory containing .json.gz files (-source=dump only)")
+	dbPath := fs.String("db", "crossref.db", "Path to the SQLite database file")
+	workers := fs.Int("workers", 4, "Number of files, or journals, to process concurrently")
+	batchSize := fs.Int("batch-size", 10000, "Number of items to insert into the database per transaction")
+	schemaFlag := fs.String("schema", "raw", "Which tables to populate: raw, normalized, or both")
+	checkpointEvery := fs.Int("checkpoint-every", 10000, "Save a resume checkpoint every N items (-source=dump only)")
+	force := fs.Bool("force", false, "Reprocess files even if already marked complete (-source=dump only)")
+	dryRun := fs.Bool("dry-run", false, "Print what would be processed without ingesting items or saving checkpoints; tables are still created (-source=dump only)")
+	source := fs.String("source", "dump", "Where to read items from: dump or api")
+	mailto := fs.String("mailto", "", "Contact email to send with API requests, for Crossref's polite pool (-source=api only)")
+	since := fs.String("since", "", "Only fetch works indexed on or after this date, YYYY-MM-DD (-source=api only)")
+	fs.Parse(args)
+
+	schema, err := ingest.ParseSchema(*schemaFlag)
+	if err != nil {
+		log.Fatalf("Error parsing -schema: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", sqliteDSN(*dbPath))
+	if err != nil {
+		log.Fatalf("Error opening database: %v", err)
+	}
+	if err := ingest.InitSQLiteSchema(db); err != nil {
+		log.Fatalf("Error initializing database: %v", err)
+	}
+	if schema != ingest.SchemaRaw {
+		if err := ingest.InitNormalizedSchema(db); err != nil {
+			log.Fatalf("Error initializing normalized schema: %v", err)
+		}
+	}
+	if err := ingest.InitProcessedFilesSchema(db); err != nil {
+		log.Fatalf("Error initializing processed_files table: %v", err)
+	}
+
+	progress := ingest.NewProgressReporter(5 * time.Second)
+	defer progress.Stop()
+
+	ctx := context.Background()
+
+	switch *source {
+	case "dump":
+		runSqliteDump(ctx, db, *dbPath, *inputDir, *workers, *batchSize, schema, *checkpointEvery, *force, *dryRun, progress)
+	case "api":
+		runSqliteAPI(ctx, db, *dbPath, *workers, *batchSize, schema, *mailto, *since, progress)
+	default:
+		log.Fatalf("Unknown -source %q: must be dump or api", *source)
+	}
+
+	db.Close()
+}
+
+func runSqliteDump(ctx context.Context, db *sql.DB, dbPath, inputDir string, workers, batchSize int, schema ingest.Schema, checkpointEvery int, force, dryRun bool, progress *ingest.ProgressReporter) {
+	filter, err := ingest.LoadJournalFilterFromDB(db)
+	if err != nil {
+		log.Fatalf("Error loading journal filter: %v", err)
+	}
+
+	store := ingest.NewDBCheckpointStore(db, sq.Question)
+
+	err = ingest.WalkFiles(inputDir, workers, func(path string) error {
+		// SQLite only allows one writer at a time, but each worker still
+		// gets its own handle so a file's batch commits independently;
+		// sqliteDSN's busy_timeout makes SQLite wait out a concurrent
+		// writer instead of failing immediately, and SQLiteSink.Flush
+		// retries on top of that for contention that outlasts it.
+		workerDB, err := sql.Open("sqlite3", sqliteDSN(dbPath))
+		if err != nil {
+			return fmt.Errorf("error opening database: %w", err)
+		}
+
+		sink := ingest.NewSQLiteSink(workerDB, batchSize, schema)
+		defer sink.Close()
+		return ingest.IngestFileResumable(ctx, path, sink, filter, progress, store, force, dryRun, checkpointEvery)
+	})
+	if err != nil {
+		log.Fatalf("Error walking directory: %v", err)
+	}
+}
+
+func runSqliteAPI(ctx context.Context, db *sql.DB, dbPath string, workers, batchSize int, schema ingest.Schema, mailto, since string, progress *ingest.ProgressReporter) {
+	journals, err := ingest.ListEnabledJournals(db)
+	if err != nil {
+		log.Fatalf("Error listing enabled journals: %v", err)
+	}
+
+	client := ingest.NewCrossrefAPIClient(mailto)
+
+	err = ingest.WalkJournals(journals, workers, func(journal string) error {
+		workerDB, err := sql.Open("sqlite3", sqliteDSN(dbPath))
+		if err != nil {
+			return fmt.Errorf("error opening database: %w", err)
+		}
+
+		sink := ingest.NewSQLiteSink(workerDB, batchSize, schema)
+		defer sink.Close()
+		return ingest.IngestJournalAPI(ctx, client, journal, since, sink, progress)
+	})
+	if err != nil {
+		log.Fatalf("Error fetching journals: %v", err)
+	}
+}
+
+// sqliteDSN builds a DSN for path with a busy_timeout well above the
+// driver's 5s default, since concurrent workers writing to the same file
+// routinely contend for longer than that under -schema=normalized (each
+// item is several statements) or a large -batch-size.
+func sqliteDSN(path string) string {
+	return fmt.Sprintf("%s?_busy_timeout=30000", path)
+}
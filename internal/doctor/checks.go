@@ -0,0 +1,147 @@
+package doctor
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func checkDuplicateDOIs(db *sql.DB) (Check, error) {
+	rows, err := db.Query(`
+		SELECT data->>'DOI'
+		FROM articles
+		WHERE data->>'DOI' IS NOT NULL
+		GROUP BY data->>'DOI'
+		HAVING COUNT(*) > 1
+	`)
+	if err != nil {
+		return Check{}, fmt.Errorf("error checking duplicate DOIs: %w", err)
+	}
+
+	samples, count, err := collectSamples(rows)
+	if err != nil {
+		return Check{}, fmt.Errorf("error checking duplicate DOIs: %w", err)
+	}
+
+	return Check{
+		Name:    "duplicate DOIs in articles",
+		Count:   count,
+		Samples: samples,
+		Remediation: `DELETE FROM articles a USING articles b
+  WHERE a.ctid < b.ctid AND a.data->>'DOI' = b.data->>'DOI';`,
+	}, nil
+}
+
+func checkUnresolvedJournals(db *sql.DB) (Check, error) {
+	rows, err := db.Query(`
+		SELECT DISTINCT a.data->'container-title'->>0
+		FROM articles a
+		WHERE a.data->'container-title'->>0 IS NOT NULL
+		  AND NOT EXISTS (
+			SELECT 1 FROM journals j WHERE j.name = a.data->'container-title'->>0
+		  )
+	`)
+	if err != nil {
+		return Check{}, fmt.Errorf("error checking unresolved journals: %w", err)
+	}
+
+	samples, count, err := collectSamples(rows)
+	if err != nil {
+		return Check{}, fmt.Errorf("error checking unresolved journals: %w", err)
+	}
+
+	return Check{
+		Name:        "distinct container-titles in articles with no matching journals row",
+		Count:       count,
+		Samples:     samples,
+		Remediation: `INSERT INTO journals (name) VALUES ('<journal name>');`,
+	}, nil
+}
+
+func checkMissingRequiredFields(db *sql.DB) (Check, error) {
+	rows, err := db.Query(`
+		SELECT id::text
+		FROM articles
+		WHERE data->>'DOI' IS NULL OR data->>'DOI' = ''
+		   OR data->'title' IS NULL
+		   OR data->'container-title' IS NULL
+		   OR data->'issued' IS NULL
+	`)
+	if err != nil {
+		return Check{}, fmt.Errorf("error checking required fields: %w", err)
+	}
+
+	samples, count, err := collectSamples(rows)
+	if err != nil {
+		return Check{}, fmt.Errorf("error checking required fields: %w", err)
+	}
+
+	return Check{
+		Name:    "articles missing a required field (DOI/title/container-title/issued)",
+		Count:   count,
+		Samples: samples,
+		Remediation: `SELECT id, data FROM articles
+  WHERE data->>'DOI' IS NULL OR data->'title' IS NULL
+     OR data->'container-title' IS NULL OR data->'issued' IS NULL;
+-- re-fetch or delete the offending rows`,
+	}, nil
+}
+
+func checkOrphanReferences(db *sql.DB) (Check, error) {
+	rows, err := db.Query(`
+		SELECT DISTINCT r.ref_doi
+		FROM article_references r
+		WHERE r.ref_doi IS NOT NULL AND r.ref_doi <> ''
+		  AND NOT EXISTS (
+			SELECT 1 FROM articles_normalized a WHERE a.doi = r.ref_doi
+		  )
+	`)
+	if err != nil {
+		if isMissingRelation(err) {
+			return Check{
+				Name:        "orphan references (article_references.ref_doi with no matching article)",
+				Skipped:     true,
+				Remediation: "re-ingest with -schema=normalized or -schema=both to populate article_references",
+			}, nil
+		}
+		return Check{}, fmt.Errorf("error checking orphan references: %w", err)
+	}
+
+	samples, count, err := collectSamples(rows)
+	if err != nil {
+		return Check{}, fmt.Errorf("error checking orphan references: %w", err)
+	}
+
+	return Check{
+		Name:    "orphan references (article_references.ref_doi with no matching article)",
+		Count:   count,
+		Samples: samples,
+		Remediation: `DELETE FROM article_references r
+  WHERE NOT EXISTS (SELECT 1 FROM articles_normalized a WHERE a.doi = r.ref_doi);`,
+	}, nil
+}
+
+func checkEmptyEnabledJournals(db *sql.DB) (Check, error) {
+	rows, err := db.Query(`
+		SELECT j.name
+		FROM journals j
+		WHERE j.enabled
+		  AND NOT EXISTS (
+			SELECT 1 FROM articles a WHERE a.data->'container-title'->>0 = j.name
+		  )
+	`)
+	if err != nil {
+		return Check{}, fmt.Errorf("error checking empty enabled journals: %w", err)
+	}
+
+	samples, count, err := collectSamples(rows)
+	if err != nil {
+		return Check{}, fmt.Errorf("error checking empty enabled journals: %w", err)
+	}
+
+	return Check{
+		Name:        "journals enabled with zero ingested articles",
+		Count:       count,
+		Samples:     samples,
+		Remediation: `UPDATE journals SET enabled = false WHERE name = '<journal name>'; -- or re-ingest if the dump should contain it`,
+	}, nil
+}
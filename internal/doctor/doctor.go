@@ -0,0 +1,92 @@
+// Package doctor runs consistency checks over an ingested Crossref corpus,
+// in the spirit of CockroachDB's `debug doctor` command.
+package doctor
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// maxSamples caps how many offending IDs a Check reports, so a failing
+// check on a huge corpus doesn't flood the report.
+const maxSamples = 5
+
+// Check is the result of one consistency check against the corpus.
+type Check struct {
+	Name        string
+	Count       int
+	Samples     []string
+	Remediation string
+	// Skipped is set when the check couldn't run, e.g. because the
+	// normalized schema hasn't been populated.
+	Skipped bool
+}
+
+// Failed reports whether the check found any offending rows.
+func (c Check) Failed() bool { return !c.Skipped && c.Count > 0 }
+
+// Report is every check's result, in the order they ran.
+type Report struct {
+	Checks []Check
+}
+
+// Failed reports whether any check in the report failed.
+func (r Report) Failed() bool {
+	for _, c := range r.Checks {
+		if c.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// Run executes every consistency check against db, which is expected to
+// already have search_path set to the languageingenetics schema.
+func Run(db *sql.DB) (Report, error) {
+	checks := []func(*sql.DB) (Check, error){
+		checkDuplicateDOIs,
+		checkUnresolvedJournals,
+		checkMissingRequiredFields,
+		checkOrphanReferences,
+		checkEmptyEnabledJournals,
+	}
+
+	var report Report
+	for _, check := range checks {
+		c, err := check(db)
+		if err != nil {
+			return Report{}, fmt.Errorf("error running check: %w", err)
+		}
+		report.Checks = append(report.Checks, c)
+	}
+	return report, nil
+}
+
+// collectSamples drains rows of single-column string results, counting
+// every row but keeping only the first maxSamples values.
+func collectSamples(rows *sql.Rows) (samples []string, count int, err error) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return nil, 0, err
+		}
+		count++
+		if len(samples) < maxSamples {
+			samples = append(samples, s)
+		}
+	}
+	return samples, count, rows.Err()
+}
+
+// isMissingRelation reports whether err is Postgres's "relation does not
+// exist" error (SQLSTATE 42P01), used to skip checks that depend on the
+// normalized schema when it hasn't been populated.
+func isMissingRelation(err error) bool {
+	type pqError interface{ SQLState() string }
+	if pe, ok := err.(pqError); ok {
+		return pe.SQLState() == "42P01"
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteText prints a human-readable report to w: one line per check, with
+// a sample of offending IDs and a remediation hint for anything that
+// failed.
+func (r Report) WriteText(w io.Writer) {
+	for _, c := range r.Checks {
+		status := "OK"
+		switch {
+		case c.Skipped:
+			status = "SKIP"
+		case c.Failed():
+			status = "FAIL"
+		}
+
+		fmt.Fprintf(w, "[%s] %s: %d\n", status, c.Name, c.Count)
+
+		if c.Skipped {
+			fmt.Fprintf(w, "    %s\n", c.Remediation)
+			continue
+		}
+		if c.Failed() {
+			fmt.Fprintf(w, "    sample: %s\n", strings.Join(c.Samples, ", "))
+			fmt.Fprintf(w, "    fix:\n      %s\n", strings.ReplaceAll(c.Remediation, "\n", "\n      "))
+		}
+	}
+}
+
+// WriteJSON prints a machine-readable report to w.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.Checks)
+}
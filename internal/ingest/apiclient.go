@@ -0,0 +1,117 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const crossrefAPIBase = "https://api.crossref.org/works"
+
+const maxFetchRetries = 5
+
+// CrossrefAPIClient fetches works from the Crossref REST API, used as a
+// lighter-weight alternative to downloading the full public-data dump.
+type CrossrefAPIClient struct {
+	httpClient *http.Client
+	mailto     string
+}
+
+// NewCrossrefAPIClient returns a client that identifies itself with mailto
+// in its User-Agent to use Crossref's polite pool (higher rate limits and
+// more reliable service). mailto may be empty.
+func NewCrossrefAPIClient(mailto string) *CrossrefAPIClient {
+	return &CrossrefAPIClient{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		mailto:     mailto,
+	}
+}
+
+func (c *CrossrefAPIClient) userAgent() string {
+	const product = "crossref-ingest/1.0 (https://github.com/language-in-genetics/language-analysis)"
+	if c.mailto == "" {
+		return product
+	}
+	return fmt.Sprintf("%s mailto:%s", product, c.mailto)
+}
+
+type worksResponse struct {
+	Message struct {
+		Items      []json.RawMessage `json:"items"`
+		NextCursor string            `json:"next-cursor"`
+	} `json:"message"`
+}
+
+// FetchWorks fetches one page of results matching filterExpr (a raw
+// Crossref filter expression, e.g.
+// "container-title:Heredity,from-index-date:2024-01-01") starting at
+// cursor ("*" for the first page). It retries with exponential backoff on
+// 429 and 5xx responses. It returns the page's items and the cursor for
+// the next page, or "" once the results are exhausted.
+func (c *CrossrefAPIClient) FetchWorks(ctx context.Context, filterExpr, cursor string, rows int) ([]json.RawMessage, string, error) {
+	q := url.Values{}
+	q.Set("filter", filterExpr)
+	q.Set("rows", fmt.Sprintf("%d", rows))
+	q.Set("cursor", cursor)
+	reqURL := crossrefAPIBase + "?" + q.Encode()
+
+	var lastErr error
+	for attempt := 0; attempt < maxFetchRetries; attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(math.Pow(2, float64(attempt))) * time.Second
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return nil, "", ctx.Err()
+			}
+		}
+
+		items, nextCursor, retryable, err := c.fetchOnce(ctx, reqURL)
+		if err == nil {
+			return items, nextCursor, nil
+		}
+		if !retryable {
+			return nil, "", err
+		}
+		lastErr = err
+	}
+
+	return nil, "", fmt.Errorf("giving up after %d attempts: %w", maxFetchRetries, lastErr)
+}
+
+func (c *CrossrefAPIClient) fetchOnce(ctx context.Context, reqURL string) (items []json.RawMessage, nextCursor string, retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("error building request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, "", true, fmt.Errorf("error fetching %s: %w", reqURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return nil, "", true, fmt.Errorf("crossref API returned %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, "", false, fmt.Errorf("crossref API returned %d: %s", resp.StatusCode, body)
+	}
+
+	var parsed worksResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, "", false, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	if len(parsed.Message.Items) == 0 {
+		return nil, "", false, nil
+	}
+	return parsed.Message.Items, parsed.Message.NextCursor, false, nil
+}
@@ -0,0 +1,30 @@
+package ingest
+
+import "time"
+
+// Checkpoint tracks processing progress for one dump file, enabling
+// resume-after-crash and skip-if-unchanged on re-runs.
+type Checkpoint struct {
+	Path        string
+	SHA256      string
+	ItemCount   int
+	LastOffset  int64
+	CompletedAt *time.Time
+}
+
+// CheckpointStore persists per-file Checkpoints. The postgres and sqlite
+// sinks keep theirs in a processed_files table; the file sink, which has
+// no database, keeps a JSON sidecar.
+type CheckpointStore interface {
+	// Load returns filename's checkpoint, or ok=false if none is recorded.
+	Load(filename string) (cp Checkpoint, ok bool, err error)
+	// SaveProgress upserts filename's checkpoint with the given hash and
+	// offset, leaving it marked incomplete.
+	SaveProgress(filename, sha256 string, offset int64) error
+	// MarkComplete upserts filename's checkpoint with the given hash,
+	// marking it fully processed. It must upsert rather than update in
+	// place: a file whose item count never crosses checkpointEvery never
+	// gets a row from SaveProgress, so MarkComplete is sometimes the only
+	// write a file's checkpoint ever gets.
+	MarkComplete(filename, sha256 string, itemCount int) error
+}
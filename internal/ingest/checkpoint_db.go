@@ -0,0 +1,97 @@
+package ingest
+
+import (
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// InitProcessedFilesSchema creates the processed_files table used to
+// checkpoint DB-backed ingestion.
+func InitProcessedFilesSchema(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS processed_files (
+			path TEXT PRIMARY KEY,
+			sha256 TEXT NOT NULL,
+			item_count INTEGER NOT NULL DEFAULT 0,
+			last_offset BIGINT NOT NULL DEFAULT 0,
+			completed_at TIMESTAMP
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating processed_files table: %w", err)
+	}
+	return nil
+}
+
+// DBCheckpointStore stores checkpoints in the processed_files table,
+// shared by the postgres and sqlite sinks.
+type DBCheckpointStore struct {
+	db          *sql.DB
+	placeholder sq.PlaceholderFormat
+}
+
+// NewDBCheckpointStore returns a CheckpointStore backed by db, using
+// placeholder for its bind-parameter syntax ($1 for postgres, ? for
+// sqlite).
+func NewDBCheckpointStore(db *sql.DB, placeholder sq.PlaceholderFormat) *DBCheckpointStore {
+	return &DBCheckpointStore{db: db, placeholder: placeholder}
+}
+
+func (s *DBCheckpointStore) builder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(s.placeholder).RunWith(s.db)
+}
+
+func (s *DBCheckpointStore) Load(filename string) (Checkpoint, bool, error) {
+	row := s.builder().
+		Select("sha256", "item_count", "last_offset", "completed_at").
+		From("processed_files").
+		Where(sq.Eq{"path": filename}).
+		QueryRow()
+
+	cp := Checkpoint{Path: filename}
+	var completedAt sql.NullTime
+	if err := row.Scan(&cp.SHA256, &cp.ItemCount, &cp.LastOffset, &completedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return Checkpoint{}, false, nil
+		}
+		return Checkpoint{}, false, fmt.Errorf("error loading checkpoint for %s: %w", filename, err)
+	}
+	if completedAt.Valid {
+		cp.CompletedAt = &completedAt.Time
+	}
+	return cp, true, nil
+}
+
+func (s *DBCheckpointStore) SaveProgress(filename, sha256 string, offset int64) error {
+	_, err := s.builder().
+		Insert("processed_files").
+		Columns("path", "sha256", "last_offset", "completed_at").
+		Values(filename, sha256, offset, nil).
+		Suffix(`ON CONFLICT (path) DO UPDATE SET
+			sha256 = EXCLUDED.sha256,
+			last_offset = EXCLUDED.last_offset,
+			completed_at = NULL`).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("error saving checkpoint for %s: %w", filename, err)
+	}
+	return nil
+}
+
+func (s *DBCheckpointStore) MarkComplete(filename, sha256 string, itemCount int) error {
+	_, err := s.builder().
+		Insert("processed_files").
+		Columns("path", "sha256", "item_count", "completed_at").
+		Values(filename, sha256, itemCount, sq.Expr("CURRENT_TIMESTAMP")).
+		Suffix(`ON CONFLICT (path) DO UPDATE SET
+			sha256 = EXCLUDED.sha256,
+			item_count = EXCLUDED.item_count,
+			completed_at = EXCLUDED.completed_at`).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("error marking %s complete: %w", filename, err)
+	}
+	return nil
+}
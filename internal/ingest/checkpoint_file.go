@@ -0,0 +1,74 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileCheckpointStore persists checkpoints as a JSON sidecar file, for the
+// file sink, which has no database to keep a processed_files table in.
+type FileCheckpointStore struct {
+	path string
+	mu   sync.Mutex
+	data map[string]Checkpoint
+}
+
+// NewFileCheckpointStore loads the checkpoint sidecar at sidecarPath, or
+// starts a fresh one if it doesn't exist yet.
+func NewFileCheckpointStore(sidecarPath string) (*FileCheckpointStore, error) {
+	store := &FileCheckpointStore{path: sidecarPath, data: map[string]Checkpoint{}}
+
+	raw, err := os.ReadFile(sidecarPath)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading checkpoint file %s: %w", sidecarPath, err)
+	}
+
+	if err := json.Unmarshal(raw, &store.data); err != nil {
+		return nil, fmt.Errorf("error parsing checkpoint file %s: %w", sidecarPath, err)
+	}
+	return store, nil
+}
+
+func (s *FileCheckpointStore) Load(filename string) (Checkpoint, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp, ok := s.data[filename]
+	return cp, ok, nil
+}
+
+func (s *FileCheckpointStore) SaveProgress(filename, sha256 string, offset int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[filename] = Checkpoint{Path: filename, SHA256: sha256, LastOffset: offset}
+	return s.writeLocked()
+}
+
+func (s *FileCheckpointStore) MarkComplete(filename, sha256 string, itemCount int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := s.data[filename]
+	cp.Path = filename
+	cp.SHA256 = sha256
+	cp.ItemCount = itemCount
+	now := time.Now()
+	cp.CompletedAt = &now
+	s.data[filename] = cp
+	return s.writeLocked()
+}
+
+func (s *FileCheckpointStore) writeLocked() error {
+	raw, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding checkpoint file: %w", err)
+	}
+	if err := os.WriteFile(s.path, raw, 0644); err != nil {
+		return fmt.Errorf("error writing checkpoint file %s: %w", s.path, err)
+	}
+	return nil
+}
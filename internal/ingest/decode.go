@@ -0,0 +1,98 @@
+// Package ingest holds the pieces of the Crossref dump pipeline shared by
+// the various cmd tools: walking .json.gz files, decoding the streaming
+// "items" array inside each one, and the sinks the decoded items are
+// written to.
+package ingest
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ItemDecoder streams the `items` array out of a Crossref dump file without
+// holding the whole decompressed file in memory.
+type ItemDecoder struct {
+	file    *os.File
+	gzip    *gzip.Reader
+	decoder *json.Decoder
+}
+
+// OpenItems opens filename, sets up the gzip and JSON decoders, and
+// advances past the `{"items": [` preamble so Next can be called
+// immediately.
+func OpenItems(filename string) (*ItemDecoder, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+
+	gzReader, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error creating gzip reader: %w", err)
+	}
+
+	decoder := json.NewDecoder(gzReader)
+
+	// Read opening bracket
+	if _, err := decoder.Token(); err != nil {
+		gzReader.Close()
+		file.Close()
+		return nil, fmt.Errorf("error reading opening token: %w", err)
+	}
+
+	// Read "items" key
+	token, err := decoder.Token()
+	if err != nil {
+		gzReader.Close()
+		file.Close()
+		return nil, fmt.Errorf("error reading items key: %w", err)
+	}
+	if token != "items" {
+		gzReader.Close()
+		file.Close()
+		return nil, fmt.Errorf("expected 'items' key, got %v", token)
+	}
+
+	// Read opening bracket of items array
+	if _, err := decoder.Token(); err != nil {
+		gzReader.Close()
+		file.Close()
+		return nil, fmt.Errorf("error reading items array opening: %w", err)
+	}
+
+	return &ItemDecoder{file: file, gzip: gzReader, decoder: decoder}, nil
+}
+
+// More reports whether there is another item to decode.
+func (d *ItemDecoder) More() bool {
+	return d.decoder.More()
+}
+
+// Next decodes the next item into v, which should be a pointer as accepted
+// by json.Unmarshal (e.g. *json.RawMessage or *map[string]interface{}).
+func (d *ItemDecoder) Next(v interface{}) error {
+	return d.decoder.Decode(v)
+}
+
+// Offset returns how many bytes of the decompressed stream (counted from
+// its start, including the `{"items": [` preamble) have been consumed so
+// far, usable as a resume checkpoint.
+func (d *ItemDecoder) Offset() int64 {
+	return d.decoder.InputOffset()
+}
+
+// Close releases the gzip reader and underlying file.
+func (d *ItemDecoder) Close() error {
+	gzErr := d.gzip.Close()
+	fileErr := d.file.Close()
+	if gzErr != nil {
+		return gzErr
+	}
+	return fileErr
+}
+
+var _ io.Closer = (*ItemDecoder)(nil)
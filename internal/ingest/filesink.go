@@ -0,0 +1,59 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileSink writes one metadata.json file per article, grouped by journal
+// and DOI, the layout the original file-output tool produced.
+type FileSink struct {
+	outputDir string
+}
+
+// NewFileSink returns a Sink that writes metadata.json files under
+// outputDir.
+func NewFileSink(outputDir string) *FileSink {
+	return &FileSink{outputDir: outputDir}
+}
+
+func (s *FileSink) Write(ctx context.Context, item map[string]interface{}) error {
+	journal, ok := containerTitle(item)
+	if !ok {
+		return fmt.Errorf("missing container-title")
+	}
+
+	doi, ok := item["DOI"].(string)
+	if !ok || doi == "" {
+		return fmt.Errorf("missing or invalid DOI")
+	}
+
+	dirPath := filepath.Join(s.outputDir, sanitizePath(journal), sanitizePath(doi))
+	if err := os.MkdirAll(dirPath, 0755); err != nil {
+		return fmt.Errorf("error creating directory %s: %w", dirPath, err)
+	}
+
+	filePath := filepath.Join(dirPath, "metadata.json")
+	jsonData, err := json.MarshalIndent(item, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, jsonData, 0644); err != nil {
+		return fmt.Errorf("error writing file %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+func (s *FileSink) Flush() error { return nil }
+func (s *FileSink) Close() error { return nil }
+
+// sanitizePath replaces problematic characters in path components.
+func sanitizePath(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, " ", "_"), "/", "_")
+}
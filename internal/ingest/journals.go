@@ -0,0 +1,111 @@
+package ingest
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// JournalFilter reports whether items from a given journal should be kept.
+type JournalFilter interface {
+	Allowed(journal string) bool
+}
+
+type nameSet map[string]bool
+
+func (s nameSet) Allowed(journal string) bool { return s[journal] }
+
+// AllowAll keeps every journal; used when no filter is configured.
+var AllowAll JournalFilter = allowAllFilter{}
+
+type allowAllFilter struct{}
+
+func (allowAllFilter) Allowed(string) bool { return true }
+
+// LoadJournalFilter builds a filter for the file sink from a
+// comma-separated -journals flag and/or a YAML file of journal names
+// (a plain list of strings). If neither is set, every journal is kept.
+func LoadJournalFilter(namesFlag string, yamlPath string) (JournalFilter, error) {
+	names := nameSet{}
+
+	for _, n := range splitNonEmpty(namesFlag, ",") {
+		names[n] = true
+	}
+
+	if yamlPath != "" {
+		data, err := os.ReadFile(yamlPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading journals file: %w", err)
+		}
+
+		var fromFile []string
+		if err := yaml.Unmarshal(data, &fromFile); err != nil {
+			return nil, fmt.Errorf("error parsing journals file: %w", err)
+		}
+		for _, n := range fromFile {
+			names[n] = true
+		}
+	}
+
+	if len(names) == 0 {
+		return AllowAll, nil
+	}
+	return names, nil
+}
+
+// LoadJournalFilterFromDB reads the enabled journals out of the journals
+// table, used by the postgres and sqlite sinks instead of a flag or file.
+func LoadJournalFilterFromDB(db *sql.DB) (JournalFilter, error) {
+	rows, err := db.Query(`SELECT name FROM journals WHERE enabled`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying journals: %w", err)
+	}
+	defer rows.Close()
+
+	names := nameSet{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("error scanning journal: %w", err)
+		}
+		names[name] = true
+	}
+	return names, rows.Err()
+}
+
+// ListEnabledJournals returns the names of every enabled journal, used by
+// the API source to know which journals to page through.
+func ListEnabledJournals(db *sql.DB) ([]string, error) {
+	rows, err := db.Query(`SELECT name FROM journals WHERE enabled`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying journals: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("error scanning journal: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+func splitNonEmpty(s, sep string) []string {
+	if s == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(s, sep) {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
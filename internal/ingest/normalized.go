@@ -0,0 +1,156 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"language-in-genetics/language-analysis/internal/model"
+)
+
+// Schema selects which tables a DB sink populates.
+type Schema string
+
+const (
+	SchemaRaw        Schema = "raw"
+	SchemaNormalized Schema = "normalized"
+	SchemaBoth       Schema = "both"
+)
+
+// ParseSchema validates a -schema flag value.
+func ParseSchema(s string) (Schema, error) {
+	switch Schema(s) {
+	case SchemaRaw, SchemaNormalized, SchemaBoth:
+		return Schema(s), nil
+	default:
+		return "", fmt.Errorf("invalid schema %q: must be raw, normalized, or both", s)
+	}
+}
+
+func (s Schema) wantsRaw() bool        { return s == SchemaRaw || s == SchemaBoth }
+func (s Schema) wantsNormalized() bool { return s == SchemaNormalized || s == SchemaBoth }
+
+// InitNormalizedSchema creates the normalized tables alongside the raw
+// JSONB table: articles_normalized (one row per DOI), article_authors,
+// article_references, and article_subjects (one row per child entry).
+func InitNormalizedSchema(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS articles_normalized (
+			doi TEXT PRIMARY KEY,
+			container_title TEXT,
+			title TEXT,
+			published_year INTEGER,
+			type TEXT,
+			abstract TEXT,
+			license TEXT,
+			publisher TEXT
+		);
+
+		CREATE TABLE IF NOT EXISTS article_authors (
+			doi TEXT NOT NULL,
+			ordinal INTEGER NOT NULL,
+			given TEXT,
+			family TEXT,
+			orcid TEXT,
+			affiliation TEXT
+		);
+
+		CREATE TABLE IF NOT EXISTS article_references (
+			doi TEXT NOT NULL,
+			ordinal INTEGER NOT NULL,
+			ref_doi TEXT,
+			unstructured TEXT
+		);
+
+		CREATE TABLE IF NOT EXISTS article_subjects (
+			doi TEXT NOT NULL,
+			subject TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating normalized tables: %w", err)
+	}
+	return nil
+}
+
+// writeNormalized upserts article and its children into the normalized
+// tables inside txn, using placeholder for the DB's bind-parameter syntax
+// ($1 for postgres, ? for sqlite).
+func writeNormalized(ctx context.Context, txn *sql.Tx, placeholder sq.PlaceholderFormat, article model.Article) error {
+	if article.DOI == "" {
+		return fmt.Errorf("article has no DOI")
+	}
+
+	builder := sq.StatementBuilder.PlaceholderFormat(placeholder).RunWith(txn)
+
+	_, err := builder.Insert("articles_normalized").
+		Columns("doi", "container_title", "title", "published_year", "type", "abstract", "license", "publisher").
+		Values(article.DOI, article.FirstContainerTitle(), article.FirstTitle(), article.PublishedYear(), article.Type, article.Abstract, article.FirstLicenseURL(), article.Publisher).
+		Suffix(`ON CONFLICT (doi) DO UPDATE SET
+			container_title = EXCLUDED.container_title,
+			title = EXCLUDED.title,
+			published_year = EXCLUDED.published_year,
+			type = EXCLUDED.type,
+			abstract = EXCLUDED.abstract,
+			license = EXCLUDED.license,
+			publisher = EXCLUDED.publisher`).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error upserting article %s: %w", article.DOI, err)
+	}
+
+	if err := replaceChildren(ctx, builder, "article_authors", article.DOI,
+		[]string{"doi", "ordinal", "given", "family", "orcid", "affiliation"},
+		func(ins sq.InsertBuilder) sq.InsertBuilder {
+			for i, a := range article.Author {
+				ins = ins.Values(article.DOI, i, a.Given, a.Family, a.ORCID, a.FirstAffiliation())
+			}
+			return ins
+		}, len(article.Author) > 0); err != nil {
+		return fmt.Errorf("error writing authors for %s: %w", article.DOI, err)
+	}
+
+	if err := replaceChildren(ctx, builder, "article_references", article.DOI,
+		[]string{"doi", "ordinal", "ref_doi", "unstructured"},
+		func(ins sq.InsertBuilder) sq.InsertBuilder {
+			for i, r := range article.Reference {
+				ins = ins.Values(article.DOI, i, r.DOI, r.Unstructured)
+			}
+			return ins
+		}, len(article.Reference) > 0); err != nil {
+		return fmt.Errorf("error writing references for %s: %w", article.DOI, err)
+	}
+
+	if err := replaceChildren(ctx, builder, "article_subjects", article.DOI,
+		[]string{"doi", "subject"},
+		func(ins sq.InsertBuilder) sq.InsertBuilder {
+			for _, subject := range article.Subject {
+				ins = ins.Values(article.DOI, subject)
+			}
+			return ins
+		}, len(article.Subject) > 0); err != nil {
+		return fmt.Errorf("error writing subjects for %s: %w", article.DOI, err)
+	}
+
+	return nil
+}
+
+// replaceChildren deletes table's existing rows for doi and inserts the
+// rows addValues adds, so re-ingesting an article is idempotent even
+// though ordinal-keyed child rows have no natural unique constraint to
+// upsert on.
+func replaceChildren(ctx context.Context, builder sq.StatementBuilderType, table, doi string, columns []string, addValues func(sq.InsertBuilder) sq.InsertBuilder, hasRows bool) error {
+	if _, err := builder.Delete(table).Where(sq.Eq{"doi": doi}).ExecContext(ctx); err != nil {
+		return fmt.Errorf("error clearing %s: %w", table, err)
+	}
+
+	if !hasRows {
+		return nil
+	}
+
+	ins := addValues(builder.Insert(table).Columns(columns...))
+	_, err := ins.ExecContext(ctx)
+	return err
+}
@@ -0,0 +1,149 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// IngestFile decodes every item in filename, keeps the ones filter allows,
+// and writes them into sink, reporting each write to progress. It always
+// flushes sink before returning, even on a decode error partway through.
+func IngestFile(ctx context.Context, filename string, sink Sink, filter JournalFilter, progress *ProgressReporter) error {
+	return ingestFile(ctx, filename, sink, filter, progress, resumeState{})
+}
+
+// IngestFileResumable is IngestFile plus checkpointing: it skips files
+// already marked complete with a matching content hash, resumes partially
+// processed files by discarding items up to the last saved offset, and
+// every checkpointEvery items forces sink to flush and saves progress to
+// store for the offset that flush covers, so a crash loses at most that
+// many items of already-committed work. force reprocesses a file even if
+// it's marked complete; dryRun reports what would happen without touching
+// sink or store.
+func IngestFileResumable(ctx context.Context, filename string, sink Sink, filter JournalFilter, progress *ProgressReporter, store CheckpointStore, force, dryRun bool, checkpointEvery int) error {
+	hash, err := FileSHA256(filename)
+	if err != nil {
+		return fmt.Errorf("error hashing %s: %w", filename, err)
+	}
+
+	cp, found, err := store.Load(filename)
+	if err != nil {
+		return fmt.Errorf("error loading checkpoint for %s: %w", filename, err)
+	}
+
+	sameContent := found && cp.SHA256 == hash
+	alreadyDone := sameContent && cp.CompletedAt != nil
+
+	if alreadyDone && !force {
+		log.Printf("Skipping %s: already processed (sha256 %s)", filename, hash)
+		return nil
+	}
+
+	resumeFrom := int64(0)
+	if sameContent && !alreadyDone && !force {
+		resumeFrom = cp.LastOffset
+	}
+
+	if dryRun {
+		if resumeFrom > 0 {
+			log.Printf("[dry-run] would resume %s from offset %d", filename, resumeFrom)
+		} else {
+			log.Printf("[dry-run] would process %s from the start", filename)
+		}
+		return nil
+	}
+
+	state := resumeState{
+		store:           store,
+		hash:            hash,
+		resumeFrom:      resumeFrom,
+		checkpointEvery: checkpointEvery,
+	}
+
+	return ingestFile(ctx, filename, sink, filter, progress, state)
+}
+
+// resumeState carries the (optional) checkpointing behavior through
+// ingestFile; its zero value disables checkpointing entirely.
+type resumeState struct {
+	store           CheckpointStore
+	hash            string
+	resumeFrom      int64
+	checkpointEvery int
+}
+
+func ingestFile(ctx context.Context, filename string, sink Sink, filter JournalFilter, progress *ProgressReporter, resume resumeState) error {
+	decoder, err := OpenItems(filename)
+	if err != nil {
+		return err
+	}
+	defer decoder.Close()
+
+	count := 0
+	skipping := resume.resumeFrom > 0
+	for decoder.More() {
+		var item map[string]interface{}
+		if err := decoder.Next(&item); err != nil {
+			log.Printf("Error decoding item in %s: %v", filename, err)
+			continue
+		}
+
+		if skipping {
+			if decoder.Offset() >= resume.resumeFrom {
+				skipping = false
+			}
+			continue
+		}
+
+		if filter != nil {
+			journal, ok := containerTitle(item)
+			if !ok || !filter.Allowed(journal) {
+				continue
+			}
+		}
+
+		if err := sink.Write(ctx, item); err != nil {
+			log.Printf("Error writing item from %s: %v", filename, err)
+			continue
+		}
+
+		count++
+		progress.Add(1)
+
+		if resume.store != nil && resume.checkpointEvery > 0 && count%resume.checkpointEvery == 0 {
+			// sink.Write only buffers for the DB sinks; force a flush so the
+			// checkpoint we're about to save only ever points past items
+			// that are actually committed, not just appended to a buffer.
+			if err := sink.Flush(); err != nil {
+				return fmt.Errorf("error flushing sink for %s: %w", filename, err)
+			}
+			if err := resume.store.SaveProgress(filename, resume.hash, decoder.Offset()); err != nil {
+				return fmt.Errorf("error saving checkpoint for %s: %w", filename, err)
+			}
+		}
+	}
+
+	if err := sink.Flush(); err != nil {
+		return fmt.Errorf("error flushing sink for %s: %w", filename, err)
+	}
+
+	if resume.store != nil {
+		if err := resume.store.MarkComplete(filename, resume.hash, count); err != nil {
+			return fmt.Errorf("error marking %s complete: %w", filename, err)
+		}
+	}
+
+	log.Printf("Completed processing %s: wrote %d items", filename, count)
+	return nil
+}
+
+// containerTitle returns the first container-title entry on item, if any.
+func containerTitle(item map[string]interface{}) (string, bool) {
+	ct, ok := item["container-title"].([]interface{})
+	if !ok || len(ct) == 0 {
+		return "", false
+	}
+	journal, ok := ct[0].(string)
+	return journal, ok
+}
@@ -0,0 +1,13 @@
+package ingest
+
+import "context"
+
+// Sink is where decoded Crossref items end up: a directory of per-article
+// JSON files, a Postgres table, or a SQLite database. Write may buffer;
+// Flush forces out anything buffered so progress survives a crash between
+// files.
+type Sink interface {
+	Write(ctx context.Context, item map[string]interface{}) error
+	Flush() error
+	Close() error
+}
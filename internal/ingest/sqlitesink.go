@@ -0,0 +1,157 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattn/go-sqlite3"
+
+	"language-in-genetics/language-analysis/internal/model"
+)
+
+// maxFlushRetries bounds how many times Flush attempts a batch (the first
+// try included) while it fails with SQLITE_BUSY/SQLITE_LOCKED, backing off
+// between attempts. Each
+// worker opens its own connection to the same file, so concurrent batch
+// transactions can still collide even with a busy_timeout set.
+const maxFlushRetries = 5
+
+// InitSQLiteSchema creates the articles and journals tables, mirroring the
+// postgres schema so small deployments don't need Postgres.
+func InitSQLiteSchema(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS articles (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			data JSON NOT NULL
+		);
+
+		CREATE UNIQUE INDEX IF NOT EXISTS articles_doi_idx
+			ON articles (json_extract(data, '$.DOI'));
+
+		CREATE TABLE IF NOT EXISTS journals (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			enabled BOOLEAN NOT NULL DEFAULT 1
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating tables: %w", err)
+	}
+	return nil
+}
+
+// SQLiteSink batches items into a single transaction per batchSize items,
+// SQLite's equivalent of the postgres sink's COPY batching.
+type SQLiteSink struct {
+	db        *sql.DB
+	batchSize int
+	schema    Schema
+	buf       []json.RawMessage
+}
+
+// NewSQLiteSink returns a Sink that writes into db in batches of
+// batchSize items according to schema, one transaction per batch.
+func NewSQLiteSink(db *sql.DB, batchSize int, schema Schema) *SQLiteSink {
+	return &SQLiteSink{db: db, batchSize: batchSize, schema: schema, buf: make([]json.RawMessage, 0, batchSize)}
+}
+
+func (s *SQLiteSink) Write(ctx context.Context, item map[string]interface{}) error {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("error marshaling item: %w", err)
+	}
+
+	s.buf = append(s.buf, raw)
+	if len(s.buf) >= s.batchSize {
+		return s.Flush()
+	}
+	return nil
+}
+
+// Flush commits the buffered batch, retrying with backoff if SQLite
+// reports the database as busy or locked, which is expected under
+// concurrent workers writing to the same file.
+func (s *SQLiteSink) Flush() error {
+	if len(s.buf) == 0 {
+		return nil
+	}
+
+	var err error
+	for attempt := 0; attempt < maxFlushRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(100 * time.Millisecond * time.Duration(attempt))
+		}
+		if err = s.flushOnce(); err == nil || !isSQLiteBusy(err) {
+			// Clear the batch here, not in flushOnce: a busy/locked error
+			// keeps retrying against the same buffer above, but any other
+			// outcome - success or a permanent error like a bad item - must
+			// not leave a non-empty buffer, or every later Write/Flush
+			// would re-trigger the same failure on the same batch forever.
+			s.buf = s.buf[:0]
+			return err
+		}
+	}
+	s.buf = s.buf[:0]
+	return fmt.Errorf("giving up after %d attempts: %w", maxFlushRetries, err)
+}
+
+func isSQLiteBusy(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+	return false
+}
+
+func (s *SQLiteSink) flushOnce() error {
+	txn, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+
+	if s.schema.wantsRaw() {
+		stmt, err := txn.Prepare(`
+			INSERT INTO articles (data) VALUES (?)
+			ON CONFLICT (json_extract(data, '$.DOI')) DO UPDATE SET data = excluded.data
+		`)
+		if err != nil {
+			txn.Rollback()
+			return fmt.Errorf("error preparing insert: %w", err)
+		}
+		for _, item := range s.buf {
+			if _, err := stmt.Exec(string(item)); err != nil {
+				stmt.Close()
+				txn.Rollback()
+				return fmt.Errorf("error inserting item: %w", err)
+			}
+		}
+		stmt.Close()
+	}
+
+	if s.schema.wantsNormalized() {
+		for _, item := range s.buf {
+			var article model.Article
+			if err := json.Unmarshal(item, &article); err != nil {
+				txn.Rollback()
+				return fmt.Errorf("error decoding article: %w", err)
+			}
+			if err := writeNormalized(context.Background(), txn, sq.Question, article); err != nil {
+				txn.Rollback()
+				return err
+			}
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteSink) Close() error { return s.db.Close() }
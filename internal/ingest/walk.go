@@ -0,0 +1,147 @@
+package ingest
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WalkFiles lists the .json.gz files under dir and runs process on each one
+// using a pool of worker goroutines, one file in flight per worker. It
+// blocks until every file has been processed.
+func WalkFiles(dir string, workers int, process func(path string) error) error {
+	files, err := findDumpFiles(dir)
+	if err != nil {
+		return fmt.Errorf("error walking directory: %w", err)
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	paths := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				log.Printf("Processing file: %s", path)
+				if err := process(path); err != nil {
+					log.Printf("Error processing file %s: %v", path, err)
+				}
+			}
+		}()
+	}
+
+	for _, path := range files {
+		paths <- path
+	}
+	close(paths)
+	wg.Wait()
+
+	return nil
+}
+
+// WalkJournals runs process on each of journals using a pool of worker
+// goroutines, one journal in flight per worker. It blocks until every
+// journal has been processed.
+func WalkJournals(journals []string, workers int, process func(journal string) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	names := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for journal := range names {
+				log.Printf("Fetching journal: %s", journal)
+				if err := process(journal); err != nil {
+					log.Printf("Error fetching journal %s: %v", journal, err)
+				}
+			}
+		}()
+	}
+
+	for _, journal := range journals {
+		names <- journal
+	}
+	close(names)
+	wg.Wait()
+
+	return nil
+}
+
+func findDumpFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if !strings.HasSuffix(path, ".json.gz") {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// ProgressReporter aggregates per-file item counts from concurrent workers
+// and prints aggregate throughput every interval.
+type ProgressReporter struct {
+	count    int64
+	interval time.Duration
+	done     chan struct{}
+}
+
+// NewProgressReporter starts a reporter goroutine that prints the running
+// total and items/sec every interval, until Stop is called.
+func NewProgressReporter(interval time.Duration) *ProgressReporter {
+	r := &ProgressReporter{interval: interval, done: make(chan struct{})}
+	go r.run()
+	return r
+}
+
+// Add records n more items having been processed.
+func (r *ProgressReporter) Add(n int) {
+	atomic.AddInt64(&r.count, int64(n))
+}
+
+func (r *ProgressReporter) run() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	var last int64
+	for {
+		select {
+		case <-ticker.C:
+			total := atomic.LoadInt64(&r.count)
+			rate := float64(total-last) / r.interval.Seconds()
+			log.Printf("Progress: %d items processed (%.1f items/sec)", total, rate)
+			last = total
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// Stop halts the reporter goroutine and prints a final total.
+func (r *ProgressReporter) Stop() {
+	close(r.done)
+	log.Printf("Done: %d items processed", atomic.LoadInt64(&r.count))
+}
@@ -0,0 +1,98 @@
+// Package model holds the typed subset of Crossref's work schema we care
+// about, used to populate the normalized tables alongside the raw JSONB.
+package model
+
+// Article is a Crossref "work" record, trimmed to the fields the
+// normalized schema stores.
+type Article struct {
+	DOI            string      `json:"DOI"`
+	Title          []string    `json:"title"`
+	ContainerTitle []string    `json:"container-title"`
+	Type           string      `json:"type"`
+	Publisher      string      `json:"publisher"`
+	Abstract       string      `json:"abstract"`
+	License        []License   `json:"license"`
+	Author         []Author    `json:"author"`
+	Reference      []Reference `json:"reference"`
+	Subject        []string    `json:"subject"`
+	Issued         DateParts   `json:"issued"`
+}
+
+// Author is one entry in a work's author list.
+type Author struct {
+	Given       string        `json:"given"`
+	Family      string        `json:"family"`
+	ORCID       string        `json:"ORCID"`
+	Affiliation []Affiliation `json:"affiliation"`
+}
+
+// Affiliation names an institution an author is affiliated with.
+type Affiliation struct {
+	Name string `json:"name"`
+}
+
+// Reference is one entry in a work's reference list. Unstructured is set
+// instead of DOI when Crossref couldn't resolve the citation.
+type Reference struct {
+	DOI          string `json:"DOI"`
+	Unstructured string `json:"unstructured"`
+}
+
+// License is one entry in a work's license list.
+type License struct {
+	URL string `json:"URL"`
+}
+
+// DateParts mirrors Crossref's nested date-parts encoding, e.g.
+// {"date-parts": [[2019, 3, 1]]}.
+type DateParts struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// Year returns the publication year, or 0 if none is present.
+func (d DateParts) Year() int {
+	if len(d.DateParts) > 0 && len(d.DateParts[0]) > 0 {
+		return d.DateParts[0][0]
+	}
+	return 0
+}
+
+// PublishedYear returns the article's issued year, or 0 if none is present.
+func (a Article) PublishedYear() int {
+	return a.Issued.Year()
+}
+
+// FirstTitle returns the work's first title, or "" if it has none.
+func (a Article) FirstTitle() string {
+	if len(a.Title) == 0 {
+		return ""
+	}
+	return a.Title[0]
+}
+
+// FirstContainerTitle returns the work's journal name, or "" if it has
+// none.
+func (a Article) FirstContainerTitle() string {
+	if len(a.ContainerTitle) == 0 {
+		return ""
+	}
+	return a.ContainerTitle[0]
+}
+
+// FirstLicenseURL returns the first license URL, or "" if the work has
+// none.
+func (a Article) FirstLicenseURL() string {
+	if len(a.License) == 0 {
+		return ""
+	}
+	return a.License[0].URL
+}
+
+// FirstAffiliation returns an author's first affiliation name, or "" if
+// they have none.
+func (a Author) FirstAffiliation() string {
+	if len(a.Affiliation) == 0 {
+		return ""
+	}
+	return a.Affiliation[0].Name
+}